@@ -13,13 +13,13 @@
 // - Only spawn specified number of goroutines to consume the task
 //
 // - `Wait` will block until tasks are finished or canceled, and return with the queue length
-//
 package cogroup
 
 import (
 	"context"
 	"fmt"
 	"os"
+	"runtime"
 	"runtime/debug"
 	"sync"
 )
@@ -33,30 +33,108 @@ import (
 // `f` is job to consume
 type Worker func(ctx context.Context, i int, f func(context.Context) error)
 
-// CoGroup Coroutine group struct holds the group state: the task queue, context and signals.
+// CoGroup Coroutine group handle returned to callers. It holds no state of
+// its own beyond `core` so that, unlike the worker goroutines, it can
+// become unreachable (and be finalized) independently of them.
 type CoGroup struct {
+	*core
+}
+
+// core holds the group state: the task queue, context and signals. Worker
+// and dispatcher goroutines close over `core` rather than `CoGroup`, so a
+// `CoGroup` that's dropped without calling `Wait` can still be finalized
+// while those goroutines run.
+type core struct {
 	worker Worker
-	ctx    context.Context                  // Group context
+	ctx    context.Context                  // Group context, derived from the caller's context
+	cancel context.CancelFunc               // Cancels the derived group context
 	wg     sync.WaitGroup                   // Group goroutine wait group
 	ch     chan func(context.Context) error // Task chan
 	sink   bool                             // Use group context or not
 	n      int                              // Number of workers to spawn
+
+	cancelOnError bool      // Cancel the group context on the first task error
+	errOnce       sync.Once // Guards capturing the first task error
+	err           error     // First non-nil error observed from a task
+
+	sem chan struct{} // Semaphore limiting tasks submitted via Go/TryGo, nil means unlimited
+
+	schedMu        sync.Mutex    // Guards schedHeap and dispatcher lifecycle
+	schedHeap      taskHeap      // Min-heap of tasks pending ScheduleAt/ScheduleAfter
+	schedSignal    chan struct{} // Wakes the dispatcher when an earlier task is inserted
+	schedStarted   bool          // Whether the dispatcher goroutine has been started
+	schedClosing   chan struct{} // Closed to tell the dispatcher to stop
+	schedCloseOnce sync.Once     // Guards closing schedClosing
+	dispatchWg     sync.WaitGroup
+
+	chCloseOnce sync.Once // Guards closing ch, shared by Wait and the finalizer
+
+	addWg        sync.WaitGroup // Tracks Add's overflow goroutines, drained before ch is closed
+	addClosing   chan struct{}  // Closed to tell Add's overflow goroutines to stop trying to enqueue
+	addCloseOnce sync.Once      // Guards closing addClosing
+
+	pauseMu sync.Mutex    // Guards gate and state
+	gate    chan struct{} // Closed while running; re-created on Pause, closed again on Resume
+	state   State         // Current Running/Paused/Stopped state
 }
 
 // Worker meta context key
 type workerKey struct{}
 
+// Option configures optional CoGroup behavior at construction time.
+type Option func(*core)
+
+// WithCancelOnError puts the group in "cancel-on-error" mode: the first
+// non-nil error observed from any task cancels the group's derived
+// context, so queued tasks drain quickly and in-flight tasks can observe
+// cancellation via their context.
+func WithCancelOnError() Option {
+	return func(c *core) {
+		c.cancelOnError = true
+	}
+}
+
 // New will create a cogroup instance without starting the group
-func New(ctx context.Context, n uint, m uint, sink bool) *CoGroup {
+func New(ctx context.Context, n uint, m uint, sink bool, opts ...Option) *CoGroup {
 	if n < 1 {
 		panic("At least one goroutine should spawned in cogroup!")
 	}
-	return &CoGroup{
-		ctx:  ctx,
-		ch:   make(chan func(context.Context) error, m),
-		n:    int(n),
-		sink: sink,
+	ctx, cancel := context.WithCancel(ctx)
+	gate := make(chan struct{})
+	close(gate)
+	c := &core{
+		ctx:        ctx,
+		cancel:     cancel,
+		ch:         make(chan func(context.Context) error, m),
+		n:          int(n),
+		sink:       sink,
+		gate:       gate,
+		addClosing: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	g := &CoGroup{core: c}
+	runtime.SetFinalizer(g, (*CoGroup).finalize)
+	return g
+}
+
+// finalize runs when a CoGroup becomes unreachable without `Wait` having
+// been called, so the worker goroutines it spawned don't leak forever: it
+// cancels the derived context, stops the schedule dispatcher (if any) so
+// it can't send on the task channel after it's closed, waits for any of
+// Add's overflow goroutines to stop trying to enqueue, and only then
+// closes the task channel.
+func (g *CoGroup) finalize() {
+	g.cancel()
+	g.stopDispatcher()
+	g.addCloseOnce.Do(func() {
+		close(g.addClosing)
+	})
+	g.addWg.Wait()
+	g.chCloseOnce.Do(func() {
+		close(g.ch)
+	})
 }
 
 // Start will initialize a cogroup and start the group goroutines.
@@ -66,8 +144,8 @@ func New(ctx context.Context, n uint, m uint, sink bool) *CoGroup {
 // Parameter `m` specifies the size of the task queue buffer, if the buffer is full, the `Insert` method will block till there's more room or a cancel signal was received.
 //
 // Parameter `sink` specifies whether to pass the group context to the task.
-func Start(ctx context.Context, n uint, m uint, sink bool) *CoGroup {
-	g := New(ctx, n, m, sink)
+func Start(ctx context.Context, n uint, m uint, sink bool, opts ...Option) *CoGroup {
+	g := New(ctx, n, m, sink, opts...)
 	g.worker = g.run
 	g.start(g.n)
 	return g
@@ -76,75 +154,99 @@ func Start(ctx context.Context, n uint, m uint, sink bool) *CoGroup {
 // StartWithWorker will register customized worker and start the group goroutines
 //
 // If worker is `nil`, the default plain worker will be used.
-func (g *CoGroup) StartWithWorker(worker Worker) {
+func (c *core) StartWithWorker(worker Worker) {
 	if worker == nil {
-		g.worker = g.run
+		c.worker = c.run
 	} else {
-		g.worker = worker
+		c.worker = worker
 	}
-	g.start(g.n)
+	c.start(c.n)
 }
 
 // TryInsert without blocking will return false when the task queue is full or closed, or the context was canceled already.
-func (g *CoGroup) TryInsert(f func(context.Context) error) (success bool) {
+func (c *core) TryInsert(f func(context.Context) error) (success bool) {
 	defer func() {
 		recover()
 	}()
 	select {
-	case g.ch <- f:
+	case c.ch <- f:
 		success = true
-	case <-g.ctx.Done():
+	case <-c.ctx.Done():
 	default:
 	}
 	return
 }
 
-// Add a task into the task queue without blocking.
-func (g *CoGroup) Add(f func(context.Context) error) {
+// Add a task into the task queue without itself blocking. If the queue is
+// full, it spawns a goroutine that blocks until the task is queued, the
+// group context is canceled, or `Wait`/the finalizer starts shutting the
+// queue down; that goroutine is tracked separately so `Wait` can drain it
+// before closing the task channel instead of racing it. Routing overflow
+// back through the queue, rather than running it directly, keeps
+// execution bounded to the group's fixed N workers.
+func (c *core) Add(f func(context.Context) error) {
 	select {
-	case g.ch <- f:
+	case c.ch <- f:
 	default:
-		go g.Insert(f)
+		c.addWg.Add(1)
+		go func() {
+			defer c.addWg.Done()
+			select {
+			case c.ch <- f:
+			case <-c.addClosing:
+			case <-c.ctx.Done():
+			}
+		}()
 	}
 }
 
 // Insert a task into the task queue with blocking if the task queue buffer is full.
 // If the group context was canceled already, it will abort with a false return.
-func (g *CoGroup) Insert(f func(context.Context) error) (success bool) {
+func (c *core) Insert(f func(context.Context) error) (success bool) {
 	defer func() {
 		recover()
 	}()
 	select {
-	case g.ch <- f:
+	case c.ch <- f:
 		success = true
-	case <-g.ctx.Done():
+	case <-c.ctx.Done():
 	}
 	return
 }
 
 // Start the coroutine group
-func (g *CoGroup) start(n int) {
+func (c *core) start(n int) {
 	for i := 0; i < n; i++ {
-		g.wg.Add(1)
-		go g.process(i)
+		c.wg.Add(1)
+		go c.process(i)
 	}
 }
 
 // Start a single coroutine
-func (g *CoGroup) process(i int) {
-	defer g.wg.Done()
+func (c *core) process(i int) {
+	defer c.wg.Done()
 	for {
+		c.pauseMu.Lock()
+		gate := c.gate
+		c.pauseMu.Unlock()
+
 		select {
-		case <-g.ctx.Done():
+		case <-c.ctx.Done():
+			return
+		case <-gate:
+		}
+
+		select {
+		case <-c.ctx.Done():
 			return
 		default:
 			select {
-			case f, ok := <-g.ch:
+			case f, ok := <-c.ch:
 				if !ok {
 					return
 				}
-				g.worker(g.ctx, i, f)
-			case <-g.ctx.Done():
+				c.worker(c.ctx, i, f)
+			case <-c.ctx.Done():
 				return
 			}
 		}
@@ -152,42 +254,152 @@ func (g *CoGroup) process(i int) {
 }
 
 // Execute a single task
-func (g *CoGroup) run(_ context.Context, i int, f func(context.Context) error) {
+func (c *core) run(_ context.Context, i int, f func(context.Context) error) {
 	defer func() {
 		if err := recover(); err != nil {
 			fmt.Fprintf(os.Stderr, "CoGroup panic captured: %v - %s", err, debug.Stack())
 		}
 	}()
 
-	if g.sink {
-		f(context.WithValue(g.ctx, workerKey{}, i))
+	var err error
+	if c.sink {
+		err = f(context.WithValue(c.ctx, workerKey{}, i))
 	} else {
-		f(context.WithValue(context.Background(), workerKey{}, i))
+		err = f(context.WithValue(context.Background(), workerKey{}, i))
 	}
-	return
+	c.setErr(err)
+}
+
+// setErr captures the first non-nil task error, and, in cancel-on-error
+// mode, cancels the group's derived context so the remaining queued tasks
+// drain quickly and in-flight tasks can observe cancellation.
+func (c *core) setErr(err error) {
+	if err == nil {
+		return
+	}
+	c.errOnce.Do(func() {
+		c.err = err
+		if c.cancelOnError {
+			c.cancel()
+		}
+	})
+}
+
+// GoTask submits a task into the task queue, mirroring errgroup.Group.Go's
+// submission signature. It returns the group context's error without
+// blocking if the group has already been canceled; otherwise it blocks
+// like `Insert` until the task is queued or the context is canceled.
+func (c *core) GoTask(f func(context.Context) error) error {
+	if err := c.ctx.Err(); err != nil {
+		return err
+	}
+	if !c.Insert(f) {
+		return c.ctx.Err()
+	}
+	return nil
+}
+
+// SetLimit limits the number of tasks submitted via `Go`/`TryGo` that may
+// be in flight at once, mirroring errgroup.Group.SetLimit. A negative n
+// removes the limit. SetLimit must not be called concurrently with Go or
+// TryGo, or while tasks submitted through them are still running.
+func (c *core) SetLimit(n int) {
+	if n < 0 {
+		c.sem = nil
+		return
+	}
+	c.sem = make(chan struct{}, n)
+}
+
+// Go runs f in a new goroutine once a semaphore token is available,
+// blocking until one is free or the group context is canceled. Unlike
+// `Add`/`Insert`, it is decoupled from the task queue buffer, so it caps
+// in-flight concurrency without allocating extra goroutines when the
+// queue is full.
+func (c *core) Go(f func(context.Context) error) {
+	if c.sem != nil {
+		select {
+		case c.sem <- struct{}{}:
+		case <-c.ctx.Done():
+			return
+		}
+	}
+	c.goRun(f)
+}
+
+// TryGo is like `Go`, but returns false immediately without blocking if
+// no semaphore token is available.
+func (c *core) TryGo(f func(context.Context) error) bool {
+	if c.sem != nil {
+		select {
+		case c.sem <- struct{}{}:
+		default:
+			return false
+		}
+	}
+	c.goRun(f)
+	return true
+}
+
+// goRun spawns the goroutine backing Go/TryGo, releasing its semaphore
+// token on completion.
+func (c *core) goRun(f func(context.Context) error) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		if c.sem != nil {
+			defer func() { <-c.sem }()
+		}
+		c.run(c.ctx, -1, f)
+	}()
 }
 
 // Size return the current length the task queue
-func (g *CoGroup) Size() int {
-	return len(g.ch)
+func (c *core) Size() int {
+	return len(c.ch)
 }
 
 // Wait till the tasks in queue are all finished, or the group was canceled by the context.
-func (g *CoGroup) Wait() int {
-	close(g.ch)
-	g.wg.Wait()
-	return len(g.ch)
+func (c *core) Wait() int {
+	dropped := c.stopDispatcher()
+	c.addCloseOnce.Do(func() {
+		close(c.addClosing)
+	})
+	c.addWg.Wait()
+	c.chCloseOnce.Do(func() {
+		close(c.ch)
+	})
+	c.releaseGate()
+	c.wg.Wait()
+	return len(c.ch) + dropped
+}
+
+// WaitErr waits the same way as `Wait`, additionally returning the first
+// non-nil error observed from any task.
+func (c *core) WaitErr() (int, error) {
+	n := c.Wait()
+	return n, c.err
 }
 
 // Reset the cogroup, it will call the group `Wait` first before do a internal reset.
-func (g *CoGroup) Reset() {
-	g.Wait()
-	g.ch = make(chan func(context.Context) error, cap(g.ch))
+func (c *core) Reset() {
+	c.Wait()
+	c.ch = make(chan func(context.Context) error, cap(c.ch))
+	c.chCloseOnce = sync.Once{}
+	c.addClosing = make(chan struct{})
+	c.addCloseOnce = sync.Once{}
+	c.errOnce = sync.Once{}
+	c.err = nil
+	c.schedMu.Lock()
+	c.schedStarted = false
+	c.schedClosing = nil
+	c.schedCloseOnce = sync.Once{}
+	c.schedMu.Unlock()
 }
 
 // GetWorkers Get the number of total group workers
-func (g *CoGroup) GetWorkers() int {
-	return g.n
+func (c *core) GetWorkers() int {
+	return c.n
 }
 
 // GetWorkerID Get worker id from the context
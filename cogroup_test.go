@@ -2,6 +2,9 @@ package cogroup
 
 import (
 	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -33,3 +36,233 @@ func Test_CoGroup(t *testing.T) {
 		t.Error("Unexpect queue length", a, b)
 	}
 }
+
+func Test_CoGroup_WaitErr(t *testing.T) {
+	boom := errors.New("boom")
+	ok := func(context.Context) error {
+		return nil
+	}
+	fail := func(context.Context) error {
+		return boom
+	}
+
+	g := Start(context.Background(), 2, 10, false)
+	g.Add(ok)
+	g.Add(fail)
+	if _, err := g.WaitErr(); err != boom {
+		t.Error("Expect first task error to be returned", err)
+	}
+
+	g = Start(context.Background(), 1, 10, false, WithCancelOnError())
+	g.Add(fail)
+	for i := 0; i < 10; i++ {
+		g.Add(ok)
+	}
+	n, err := g.WaitErr()
+	if err != boom {
+		t.Error("Expect cancel-on-error group to surface the task error", err)
+	}
+	if n == 0 {
+		t.Error("Expect cancel-on-error group to leave remaining queued tasks unconsumed", n)
+	}
+}
+
+func Test_CoGroup_AddOverflowStaysBoundedToWorkers(t *testing.T) {
+	g := Start(context.Background(), 2, 1, false)
+
+	var running int32
+	var maxRunning int32
+	block := make(chan struct{})
+	f := func(context.Context) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		<-block
+		atomic.AddInt32(&running, -1)
+		return nil
+	}
+
+	for i := 0; i < 10; i++ {
+		g.Add(f)
+	}
+	<-time.After(100 * time.Millisecond)
+	close(block)
+	g.Wait()
+
+	if maxRunning > 2 {
+		t.Error("Expect Add's overflow to stay bounded to the group's worker count", maxRunning)
+	}
+}
+
+func Test_CoGroup_Go(t *testing.T) {
+	g := Start(context.Background(), 1, 0, false)
+	g.SetLimit(2)
+
+	var running int32
+	var maxRunning int32
+	f := func(context.Context) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		<-time.After(100 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	}
+
+	for i := 0; i < 6; i++ {
+		g.Go(f)
+	}
+	g.Wait()
+
+	if maxRunning > 2 {
+		t.Error("Expect SetLimit to cap in-flight Go tasks", maxRunning)
+	}
+}
+
+func Test_CoGroup_TryGo(t *testing.T) {
+	g := Start(context.Background(), 1, 0, false)
+	g.SetLimit(1)
+
+	block := make(chan struct{})
+	g.Go(func(context.Context) error {
+		<-block
+		return nil
+	})
+	<-time.After(50 * time.Millisecond)
+
+	if g.TryGo(func(context.Context) error { return nil }) {
+		t.Error("Expect TryGo to fail without a free semaphore token")
+	}
+	close(block)
+	g.Wait()
+}
+
+func Test_CoGroup_Schedule(t *testing.T) {
+	g := Start(context.Background(), 2, 10, false)
+
+	done := make(chan int, 2)
+	start := time.Now()
+	g.ScheduleAfter(100*time.Millisecond, func(context.Context) error {
+		done <- 2
+		return nil
+	})
+	g.ScheduleAt(start.Add(20*time.Millisecond), func(context.Context) error {
+		done <- 1
+		return nil
+	})
+
+	if first := <-done; first != 1 {
+		t.Error("Expect the earlier scheduled task to fire first", first)
+	}
+	if second := <-done; second != 2 {
+		t.Error("Expect the later scheduled task to fire second", second)
+	}
+
+	if n := g.Wait(); n != 0 {
+		t.Error("Expect no tasks left once both scheduled tasks have fired", n)
+	}
+}
+
+func Test_CoGroup_Schedule_Dropped(t *testing.T) {
+	g := Start(context.Background(), 1, 10, false)
+	g.ScheduleAfter(time.Hour, func(context.Context) error { return nil })
+	n := g.Wait()
+	if n != 1 {
+		t.Error("Expect an unfired scheduled task to be counted on Wait", n)
+	}
+}
+
+func Test_CoGroup_FinalizerReclaimsWorkers(t *testing.T) {
+	runtime.GC()
+	before := runtime.NumGoroutine()
+
+	func() {
+		g := Start(context.Background(), 50, 10, false)
+		_ = g
+	}()
+
+	for i := 0; i < 10; i++ {
+		runtime.GC()
+		<-time.After(50 * time.Millisecond)
+		if runtime.NumGoroutine() <= before {
+			return
+		}
+	}
+
+	t.Error("Expect abandoned CoGroup worker goroutines to be reclaimed by the finalizer", before, runtime.NumGoroutine())
+}
+
+func Test_CoGroup_PauseResume(t *testing.T) {
+	g := Start(context.Background(), 1, 10, false)
+
+	var ran int32
+	f := func(context.Context) error {
+		atomic.AddInt32(&ran, 1)
+		return nil
+	}
+
+	g.Pause()
+	if s := g.State(); s != Paused {
+		t.Error("Expect State to report Paused", s)
+	}
+	g.Pause() // idempotent
+
+	g.Add(f)
+	g.Add(f)
+	<-time.After(100 * time.Millisecond)
+	if n := atomic.LoadInt32(&ran); n != 0 {
+		t.Error("Expect no tasks to run while paused", n)
+	}
+
+	g.Resume()
+	g.Resume() // idempotent
+	if s := g.State(); s != Running {
+		t.Error("Expect State to report Running after Resume", s)
+	}
+
+	if n := g.Wait(); n != 0 {
+		t.Error("Expect queued tasks to run after Resume", n)
+	}
+	if n := atomic.LoadInt32(&ran); n != 2 {
+		t.Error("Expect both tasks to have run", n)
+	}
+}
+
+func Test_CoGroup_FinalizerStopsDispatcher(t *testing.T) {
+	func() {
+		g := Start(context.Background(), 2, 10, false)
+		for i := 0; i < 20; i++ {
+			g.ScheduleAfter(time.Millisecond, func(context.Context) error { return nil })
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		runtime.GC()
+		<-time.After(20 * time.Millisecond)
+	}
+}
+
+func Test_CoGroup_PauseThenWait(t *testing.T) {
+	g := Start(context.Background(), 2, 10, false)
+	g.Add(func(context.Context) error { return nil })
+	g.Pause()
+
+	done := make(chan int, 1)
+	go func() {
+		done <- g.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Error("Expect Wait to return after Pause instead of hanging forever")
+	}
+}
@@ -0,0 +1,154 @@
+package cogroup
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Collector wraps a CoGroup and runs typed tasks that produce a value,
+// streaming successful results out through `Results` while aggregating
+// errors from failed tasks. It gives callers a "parallel map" pattern
+// (fan-out N calls, collect the responses) on top of CoGroup without
+// manual channel plumbing.
+//
+// Results are buffered internally without bound, so a task never blocks
+// waiting for `Results` to be drained: that would otherwise keep the
+// task's goroutine running and could deadlock `Wait` if the caller doesn't consume
+// `Results` concurrently. A dedicated forwarder goroutine moves buffered
+// results onto the public channel; see `Results` for what happens if a
+// caller never drains it.
+type Collector[T any] struct {
+	g          *CoGroup
+	joinErrors bool
+
+	bufMu   sync.Mutex
+	bufCond *sync.Cond
+	buf     []T
+	closed  bool
+	out     chan T
+
+	errMu   sync.Mutex
+	errOnce sync.Once
+	errs    []error
+}
+
+// NewCollector wraps g in a Collector. When joinErrors is true, Wait
+// returns errors.Join of every task error observed; otherwise it returns
+// only the first one.
+func NewCollector[T any](g *CoGroup, joinErrors bool) *Collector[T] {
+	c := &Collector[T]{
+		g:          g,
+		joinErrors: joinErrors,
+		out:        make(chan T),
+	}
+	c.bufCond = sync.NewCond(&c.bufMu)
+	go c.forward()
+	return c
+}
+
+// Go submits f to the underlying group. On success its result is buffered
+// for `Results`; on failure the error is aggregated and no result is
+// buffered.
+func (c *Collector[T]) Go(f func(context.Context) (T, error)) {
+	c.g.Go(func(ctx context.Context) error {
+		v, err := f(ctx)
+		if err != nil {
+			c.addErr(err)
+			return err
+		}
+		c.push(v)
+		return nil
+	})
+}
+
+// push appends a result to the buffer without blocking and wakes the
+// forwarder goroutine.
+func (c *Collector[T]) push(v T) {
+	c.bufMu.Lock()
+	c.buf = append(c.buf, v)
+	c.bufMu.Unlock()
+	c.bufCond.Signal()
+}
+
+// forward drains the buffer onto the public `out` channel until Wait has
+// marked the collector closed and the buffer runs dry, then closes
+// `out`. It runs independently of `Wait`, which returns as soon as the
+// group's tasks finish: a caller that's slow to drain `Results` only
+// stalls this goroutine, not the group's own workers or `Wait`. A caller
+// that abandons `Results` entirely (e.g. it only cares about `Wait`'s
+// error) still leaks this goroutine until the group's context is
+// canceled, at which point the blocked send gives up and `forward`
+// exits; callers that don't read `Results` should cancel the context
+// passed to the underlying group once they're done with it.
+func (c *Collector[T]) forward() {
+	defer close(c.out)
+	for {
+		c.bufMu.Lock()
+		for len(c.buf) == 0 && !c.closed {
+			c.bufCond.Wait()
+		}
+		if len(c.buf) == 0 {
+			c.bufMu.Unlock()
+			return
+		}
+		v := c.buf[0]
+		c.buf = c.buf[1:]
+		c.bufMu.Unlock()
+		select {
+		case c.out <- v:
+		case <-c.g.ctx.Done():
+			return
+		}
+	}
+}
+
+// addErr records a task error, either keeping only the first one or
+// accumulating all of them depending on joinErrors.
+func (c *Collector[T]) addErr(err error) {
+	if !c.joinErrors {
+		c.errOnce.Do(func() {
+			c.errMu.Lock()
+			c.errs = append(c.errs, err)
+			c.errMu.Unlock()
+		})
+		return
+	}
+	c.errMu.Lock()
+	c.errs = append(c.errs, err)
+	c.errMu.Unlock()
+}
+
+// Results streams the successful output of every submitted task. It is
+// closed once every result submitted before `Wait` was called has been
+// forwarded, which may happen slightly after `Wait` itself returns. If
+// the caller never drains `Results`, the forwarder goroutine blocks
+// until the group's context is canceled, so cancel it once you're done
+// with the collector. Once the group's context is canceled, `Results`
+// may be closed early, and results still buffered are dropped.
+func (c *Collector[T]) Results() <-chan T {
+	return c.out
+}
+
+// Wait waits till every submitted task has finished and returns the
+// aggregated error, if any. It does not wait for `Results` to be fully
+// drained: the forwarder goroutine keeps flushing buffered results and
+// closes the Results channel on its own once the buffer empties.
+func (c *Collector[T]) Wait() error {
+	c.g.Wait()
+
+	c.bufMu.Lock()
+	c.closed = true
+	c.bufMu.Unlock()
+	c.bufCond.Broadcast()
+
+	c.errMu.Lock()
+	defer c.errMu.Unlock()
+	if len(c.errs) == 0 {
+		return nil
+	}
+	if c.joinErrors {
+		return errors.Join(c.errs...)
+	}
+	return c.errs[0]
+}
@@ -0,0 +1,116 @@
+package cogroup
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func Test_Collector(t *testing.T) {
+	g := Start(context.Background(), 4, 10, false)
+	c := NewCollector[int](g, false)
+
+	for i := 0; i < 5; i++ {
+		i := i
+		c.Go(func(context.Context) (int, error) {
+			return i * i, nil
+		})
+	}
+
+	var sum int
+	done := make(chan struct{})
+	go func() {
+		for v := range c.Results() {
+			sum += v
+		}
+		close(done)
+	}()
+
+	if err := c.Wait(); err != nil {
+		t.Error("Unexpected collector error", err)
+	}
+	<-done
+
+	if sum != 0+1+4+9+16 {
+		t.Error("Unexpect collected sum", sum)
+	}
+}
+
+func Test_Collector_WaitDoesNotBlockOnUndrainedResults(t *testing.T) {
+	g := Start(context.Background(), 4, 2, false)
+	c := NewCollector[int](g, false)
+
+	for i := 0; i < 50; i++ {
+		c.Go(func(context.Context) (int, error) { return 1, nil })
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error("Unexpected collector error", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expect Wait to return without Results being drained concurrently")
+	}
+
+	var n int
+	for range c.Results() {
+		n++
+	}
+	if n != 50 {
+		t.Error("Expect every result to still be readable after Wait", n)
+	}
+}
+
+func Test_Collector_ForwarderExitsOnContextCancelWhenResultsAbandoned(t *testing.T) {
+	runtime.GC()
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	g := Start(ctx, 2, 10, false)
+	c := NewCollector[int](g, false)
+
+	c.Go(func(context.Context) (int, error) { return 1, nil })
+	if err := c.Wait(); err != nil {
+		t.Error("Unexpected collector error", err)
+	}
+
+	cancel()
+
+	for i := 0; i < 10; i++ {
+		runtime.GC()
+		<-time.After(50 * time.Millisecond)
+		if runtime.NumGoroutine() <= before {
+			return
+		}
+	}
+
+	t.Error("Expect forwarder goroutine to exit once the group context is canceled", before, runtime.NumGoroutine())
+}
+
+func Test_Collector_Errors(t *testing.T) {
+	boom := errors.New("boom")
+	g := Start(context.Background(), 2, 10, false)
+	c := NewCollector[int](g, true)
+
+	c.Go(func(context.Context) (int, error) { return 1, nil })
+	c.Go(func(context.Context) (int, error) { return 0, boom })
+	c.Go(func(context.Context) (int, error) { return 0, boom })
+
+	go func() {
+		for range c.Results() {
+		}
+	}()
+
+	err := c.Wait()
+	if err == nil || !errors.Is(err, boom) {
+		t.Error("Expect joined errors to include the task error", err)
+	}
+}
@@ -0,0 +1,66 @@
+package cogroup
+
+// State reports a CoGroup's current Running/Paused/Stopped state.
+type State int32
+
+const (
+	// Running is the default state: workers pull tasks off the queue.
+	Running State = iota
+	// Paused means workers have stopped pulling tasks off the queue until Resume is called.
+	Paused
+	// Stopped means the group context has been canceled.
+	Stopped
+)
+
+// Pause halts task consumption: workers finish any task already in
+// progress, then block before pulling the next one off the queue.
+// `Add`/`Insert` keep buffering into the queue (up to its capacity) while
+// paused, giving natural backpressure to producers. Pause is a no-op if
+// the group is already paused or stopped, and is safe under concurrent
+// calls.
+func (c *core) Pause() {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+	if c.state != Running {
+		return
+	}
+	c.gate = make(chan struct{})
+	c.state = Paused
+}
+
+// Resume releases workers blocked by a prior Pause. Resume is a no-op if
+// the group isn't paused, and is safe under concurrent calls.
+func (c *core) Resume() {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+	if c.state != Paused {
+		return
+	}
+	close(c.gate)
+	c.state = Running
+}
+
+// releaseGate unblocks any worker currently parked on a prior Pause, so
+// that `Wait` doesn't hang waiting on workers that are gated shut. It is
+// a no-op unless the group is paused.
+func (c *core) releaseGate() {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+	if c.state != Paused {
+		return
+	}
+	close(c.gate)
+	c.state = Running
+}
+
+// State returns the group's current Running/Paused/Stopped state.
+func (c *core) State() State {
+	select {
+	case <-c.ctx.Done():
+		return Stopped
+	default:
+	}
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+	return c.state
+}
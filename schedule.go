@@ -0,0 +1,153 @@
+package cogroup
+
+import (
+	"container/heap"
+	"context"
+	"time"
+)
+
+// scheduledTask is a single pending ScheduleAt/ScheduleAfter task, ordered
+// by its fire time.
+type scheduledTask struct {
+	at    time.Time
+	f     func(context.Context) error
+	index int
+}
+
+// taskHeap is a container/heap.Interface min-heap of scheduledTask keyed by
+// fire time.
+type taskHeap []*scheduledTask
+
+func (h taskHeap) Len() int { return len(h) }
+
+func (h taskHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+
+func (h taskHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *taskHeap) Push(x interface{}) {
+	task := x.(*scheduledTask)
+	task.index = len(*h)
+	*h = append(*h, task)
+}
+
+func (h *taskHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	task := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return task
+}
+
+// ScheduleAt submits f to run at time t, bounded by the group's workers
+// the same way as `Add`. A single dispatcher goroutine, backed by a
+// min-heap of pending tasks, sleeps until the next deadline and pushes
+// the task onto the worker channel when it fires.
+func (c *core) ScheduleAt(t time.Time, f func(context.Context) error) {
+	c.startDispatcher()
+
+	c.schedMu.Lock()
+	heap.Push(&c.schedHeap, &scheduledTask{at: t, f: f})
+	isHead := c.schedHeap[0].at.Equal(t)
+	c.schedMu.Unlock()
+
+	if isHead {
+		select {
+		case c.schedSignal <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// ScheduleAfter submits f to run after d has elapsed.
+func (c *core) ScheduleAfter(d time.Duration, f func(context.Context) error) {
+	c.ScheduleAt(time.Now().Add(d), f)
+}
+
+// startDispatcher lazily starts the single dispatcher goroutine the first
+// time a task is scheduled.
+func (c *core) startDispatcher() {
+	c.schedMu.Lock()
+	defer c.schedMu.Unlock()
+	if c.schedStarted {
+		return
+	}
+	c.schedStarted = true
+	c.schedSignal = make(chan struct{}, 1)
+	c.schedClosing = make(chan struct{})
+	c.dispatchWg.Add(1)
+	go c.dispatch()
+}
+
+// stopDispatcher tells a running dispatcher to stop and waits for it to
+// exit, returning the number of not-yet-fired tasks dropped from the
+// heap.
+func (c *core) stopDispatcher() (dropped int) {
+	c.schedMu.Lock()
+	started := c.schedStarted
+	closing := c.schedClosing
+	c.schedMu.Unlock()
+
+	if started {
+		c.schedCloseOnce.Do(func() { close(closing) })
+		c.dispatchWg.Wait()
+	}
+
+	c.schedMu.Lock()
+	dropped = len(c.schedHeap)
+	c.schedHeap = nil
+	c.schedMu.Unlock()
+	return
+}
+
+// dispatch sleeps until the next scheduled task is due, then pushes it
+// onto the worker channel. It re-arms its timer whenever ScheduleAt
+// signals that an earlier task was inserted.
+func (c *core) dispatch() {
+	defer c.dispatchWg.Done()
+
+	timer := time.NewTimer(time.Hour)
+	if !timer.Stop() {
+		<-timer.C
+	}
+	defer timer.Stop()
+
+	for {
+		c.schedMu.Lock()
+		var timerC <-chan time.Time
+		if len(c.schedHeap) > 0 {
+			wait := time.Until(c.schedHeap[0].at)
+			if wait <= 0 {
+				task := heap.Pop(&c.schedHeap).(*scheduledTask)
+				c.schedMu.Unlock()
+				select {
+				case c.ch <- task.f:
+				case <-c.ctx.Done():
+					return
+				case <-c.schedClosing:
+					return
+				}
+				continue
+			}
+			timer.Reset(wait)
+			timerC = timer.C
+		}
+		c.schedMu.Unlock()
+
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-c.schedClosing:
+			return
+		case <-c.schedSignal:
+			if timerC != nil && !timer.Stop() {
+				<-timer.C
+			}
+		case <-timerC:
+		}
+	}
+}